@@ -7,10 +7,29 @@ import (
 
 // Experiment represents the complete chaos engineering experiment configuration
 type Experiment struct {
-	Name        string     `yaml:"name"`
-	Description string     `yaml:"description"`
+	Name        string      `yaml:"name"`
+	Description string      `yaml:"description"`
 	SteadyState SteadyState `yaml:"steady_state"`
-	Scenario    Scenario   `yaml:"scenario"`
+	Scenario    Scenario    `yaml:"scenario"`
+	Safety      Safety      `yaml:"safety,omitempty"`
+}
+
+// Safety bounds how much damage a single experiment run may cause before
+// the runner halts and attempts to roll back. With every field at its zero
+// value, the runner aborts on the very first steady-state loss, matching
+// the original behavior.
+type Safety struct {
+	// AbortOnProbeFailure aborts on the first steady-state loss rather
+	// than tolerating any failures, even if the other fields are set.
+	AbortOnProbeFailure bool `yaml:"abort_on_probe_failure,omitempty"`
+
+	// MaxConsecutiveFailures allows this many steady-state losses in a
+	// row before aborting.
+	MaxConsecutiveFailures int `yaml:"max_consecutive_failures,omitempty"`
+
+	// MaxTotalFailures allows this many steady-state losses across the
+	// whole experiment before aborting.
+	MaxTotalFailures int `yaml:"max_total_failures,omitempty"`
 }
 
 // SteadyState defines how to validate the system is in a healthy state
@@ -18,12 +37,34 @@ type SteadyState struct {
 	Probes []Probe `yaml:"probes"`
 }
 
-// Probe defines a health check to validate steady state
+// Probe defines a health check to validate steady state. A failing probe
+// is retried until it passes or RetryTimeout elapses, so steady state
+// validation tolerates the brief unavailability that follows an attack
+// rather than failing on the first response.
 type Probe struct {
 	Name    string        `yaml:"name"`
 	Type    string        `yaml:"type"`
 	HTTP    *HTTPProbe   `yaml:"http,omitempty"`
 	Timeout time.Duration `yaml:"timeout"`
+
+	// Retries caps the number of attempts; 0 means unlimited (bounded only
+	// by RetryTimeout).
+	Retries int `yaml:"retries,omitempty"`
+
+	// RetryInterval is the delay between attempts. Under exponential
+	// backoff this is the initial delay.
+	RetryInterval time.Duration `yaml:"retry_interval,omitempty"`
+
+	// RetryTimeout is the overall budget for retries; 0 disables retries
+	// entirely, preserving the single-shot behavior.
+	RetryTimeout time.Duration `yaml:"retry_timeout,omitempty"`
+
+	// RetryBackoff is "constant" (default) or "exponential".
+	RetryBackoff string `yaml:"retry_backoff,omitempty"`
+
+	// MaxInterval caps the delay between attempts under exponential
+	// backoff; 0 means uncapped.
+	MaxInterval time.Duration `yaml:"max_interval,omitempty"`
 }
 
 // HTTPProbe defines HTTP-specific probe configuration
@@ -34,17 +75,122 @@ type HTTPProbe struct {
 	Headers        map[string]string `yaml:"headers,omitempty"`
 }
 
-// Scenario defines the chaos scenario to execute
+// Scenario defines the chaos scenario to execute. Each tick of Interval
+// runs every action in Actions, in order, against the selected targets.
 type Scenario struct {
-	Type     string        `yaml:"type"`
-	Selector Selector      `yaml:"selector"`
+	Actions  []ScenarioAction `yaml:"actions"`
+	Selector Selector         `yaml:"selector"`
+	Duration time.Duration    `yaml:"duration"`
+	Interval time.Duration    `yaml:"interval"`
+
+	// MaxPercentage and MaxCount cap how many of the matching targets a
+	// single experiment run may touch, as a blast-radius control. At most
+	// one should be set; 0 for both means no cap.
+	MaxPercentage int `yaml:"max_percentage,omitempty"`
+	MaxCount      int `yaml:"max_count,omitempty"`
+}
+
+// ScenarioActionTypes enumerates the scenario action types the runner
+// knows how to execute.
+var ScenarioActionTypes = map[string]bool{
+	"restart_random":    true,
+	"kill_random":       true,
+	"stop_percentage":   true,
+	"inject_latency":    true,
+	"cpu_stress":        true,
+	"memory_stress":     true,
+	"network_partition": true,
+}
+
+// ScenarioAction describes a single chaos action to execute against
+// selected targets. Only the fields relevant to Type are populated.
+type ScenarioAction struct {
+	Type string `yaml:"type"`
+
+	// stop_percentage
+	Percentage int `yaml:"percentage,omitempty"`
+
+	// inject_latency
+	Latency *LatencyAction `yaml:"latency,omitempty"`
+
+	// cpu_stress
+	CPU *CPUStressAction `yaml:"cpu_stress,omitempty"`
+
+	// memory_stress
+	Memory *MemoryStressAction `yaml:"memory_stress,omitempty"`
+}
+
+// LatencyAction configures an inject_latency action.
+type LatencyAction struct {
+	DurationMS int `yaml:"duration_ms"`
+	JitterMS   int `yaml:"jitter_ms,omitempty"`
+	Port       int `yaml:"port,omitempty"`
+}
+
+// CPUStressAction configures a cpu_stress action.
+type CPUStressAction struct {
+	Cores    int           `yaml:"cores"`
+	Duration time.Duration `yaml:"duration"`
+}
+
+// MemoryStressAction configures a memory_stress action.
+type MemoryStressAction struct {
+	MB       int           `yaml:"mb"`
 	Duration time.Duration `yaml:"duration"`
-	Interval time.Duration `yaml:"interval"`
 }
 
-// Selector defines how to select targets for chaos
+// Validate checks if the scenario action configuration is valid.
+func (a *ScenarioAction) Validate() error {
+	if !ScenarioActionTypes[a.Type] {
+		return fmt.Errorf("unsupported action type: %s", a.Type)
+	}
+
+	switch a.Type {
+	case "stop_percentage":
+		if a.Percentage <= 0 || a.Percentage > 100 {
+			return fmt.Errorf("stop_percentage requires a percentage between 1 and 100")
+		}
+	case "inject_latency":
+		if a.Latency == nil || a.Latency.DurationMS <= 0 {
+			return fmt.Errorf("inject_latency requires latency.duration_ms greater than 0")
+		}
+	case "cpu_stress":
+		if a.CPU == nil || a.CPU.Cores <= 0 || a.CPU.Duration <= 0 {
+			return fmt.Errorf("cpu_stress requires cpu_stress.cores and cpu_stress.duration greater than 0")
+		}
+	case "memory_stress":
+		if a.Memory == nil || a.Memory.MB <= 0 || a.Memory.Duration <= 0 {
+			return fmt.Errorf("memory_stress requires memory_stress.mb and memory_stress.duration greater than 0")
+		}
+	}
+
+	return nil
+}
+
+// Selector defines how to select targets for chaos. App and Backend apply
+// to every backend; the remaining fields are backend-specific and are
+// ignored by backends that don't use them.
 type Selector struct {
-	App string `yaml:"app"`
+	App     string `yaml:"app"`
+	Backend string `yaml:"backend"`
+
+	// Kubernetes-specific, except Labels, which every backend matches
+	// against its targets' labels or metadata.
+	Namespace  string            `yaml:"namespace,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	Kubeconfig string            `yaml:"kubeconfig,omitempty"`
+
+	// Docker-specific.
+	Host string `yaml:"host,omitempty"`
+
+	// EC2-specific, except Tags, which is merged with Labels into one
+	// label match set and so applies to every backend.
+	Tags   map[string]string `yaml:"tags,omitempty"`
+	Region string            `yaml:"region,omitempty"`
+
+	// Regions restricts matching targets to this allow-list of regions;
+	// empty means all regions match.
+	Regions []string `yaml:"regions,omitempty"`
 }
 
 // Validate checks if the experiment configuration is valid
@@ -57,8 +203,8 @@ func (e *Experiment) Validate() error {
 		return fmt.Errorf("at least one probe is required")
 	}
 	
-	for i, probe := range e.SteadyState.Probes {
-		if err := probe.Validate(); err != nil {
+	for i := range e.SteadyState.Probes {
+		if err := e.SteadyState.Probes[i].Validate(); err != nil {
 			return fmt.Errorf("probe %d: %w", i, err)
 		}
 	}
@@ -66,7 +212,24 @@ func (e *Experiment) Validate() error {
 	if err := e.Scenario.Validate(); err != nil {
 		return fmt.Errorf("scenario: %w", err)
 	}
-	
+
+	if err := e.Safety.Validate(); err != nil {
+		return fmt.Errorf("safety: %w", err)
+	}
+
+	return nil
+}
+
+// Validate checks if the safety configuration is valid
+func (s *Safety) Validate() error {
+	if s.MaxConsecutiveFailures < 0 {
+		return fmt.Errorf("max_consecutive_failures cannot be negative")
+	}
+
+	if s.MaxTotalFailures < 0 {
+		return fmt.Errorf("max_total_failures cannot be negative")
+	}
+
 	return nil
 }
 
@@ -99,20 +262,52 @@ func (p *Probe) Validate() error {
 	if p.Timeout == 0 {
 		p.Timeout = 30 * time.Second
 	}
-	
+
+	if p.RetryBackoff != "" && p.RetryBackoff != "constant" && p.RetryBackoff != "exponential" {
+		return fmt.Errorf("retry_backoff must be 'constant' or 'exponential'")
+	}
+
+	if p.RetryTimeout == 0 {
+		if p.Retries > 0 {
+			return fmt.Errorf("retries requires a positive retry_timeout")
+		}
+		if p.RetryInterval > 0 {
+			return fmt.Errorf("retry_interval requires a positive retry_timeout")
+		}
+		return nil
+	}
+
+	if p.RetryBackoff == "" {
+		p.RetryBackoff = "constant"
+	}
+
+	if p.RetryInterval == 0 {
+		p.RetryInterval = time.Second
+	}
+
 	return nil
 }
 
 // Validate checks if the scenario configuration is valid
 func (s *Scenario) Validate() error {
-	if s.Type != "restart_random" {
-		return fmt.Errorf("only 'restart_random' scenario type is supported")
+	if len(s.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
 	}
-	
+
+	for i, action := range s.Actions {
+		if err := action.Validate(); err != nil {
+			return fmt.Errorf("action %d: %w", i, err)
+		}
+	}
+
 	if s.Selector.App == "" {
 		return fmt.Errorf("app selector is required")
 	}
-	
+
+	if s.Selector.Backend == "" {
+		s.Selector.Backend = "fly"
+	}
+
 	if s.Duration <= 0 {
 		return fmt.Errorf("positive duration is required")
 	}
@@ -124,6 +319,18 @@ func (s *Scenario) Validate() error {
 	if s.Interval > s.Duration {
 		return fmt.Errorf("interval cannot be greater than duration")
 	}
-	
+
+	if s.MaxPercentage < 0 || s.MaxPercentage > 100 {
+		return fmt.Errorf("max_percentage must be between 0 and 100")
+	}
+
+	if s.MaxCount < 0 {
+		return fmt.Errorf("max_count cannot be negative")
+	}
+
+	if s.MaxPercentage > 0 && s.MaxCount > 0 {
+		return fmt.Errorf("max_percentage and max_count are mutually exclusive")
+	}
+
 	return nil
 }
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExperimentValidateDefaultsRetryIntervalInPlace(t *testing.T) {
+	experiment := &Experiment{
+		Name: "test",
+		SteadyState: SteadyState{
+			Probes: []Probe{
+				{
+					Name:         "probe",
+					Type:         "http",
+					HTTP:         &HTTPProbe{URL: "http://example.com"},
+					RetryTimeout: 30 * time.Second,
+				},
+			},
+		},
+		Scenario: Scenario{
+			Selector: Selector{App: "app", Backend: "fly"},
+			Actions:  []ScenarioAction{{Type: "restart_random"}},
+			Duration: 30 * time.Second,
+			Interval: time.Second,
+		},
+	}
+
+	if err := experiment.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+
+	// Validate ranges over e.SteadyState.Probes; defaults must land on the
+	// real slice element, not a throwaway range copy.
+	probe := experiment.SteadyState.Probes[0]
+	if probe.RetryInterval != time.Second {
+		t.Errorf("RetryInterval = %v, want %v", probe.RetryInterval, time.Second)
+	}
+	if probe.RetryBackoff != "constant" {
+		t.Errorf("RetryBackoff = %q, want %q", probe.RetryBackoff, "constant")
+	}
+}
+
+func TestProbeValidateRetryFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		probe   Probe
+		wantErr bool
+	}{
+		{
+			name: "retry_timeout with no retry_interval is valid",
+			probe: Probe{
+				Name: "p", Type: "http", HTTP: &HTTPProbe{URL: "http://example.com"},
+				RetryTimeout: 30 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "retries without retry_timeout is rejected",
+			probe: Probe{
+				Name: "p", Type: "http", HTTP: &HTTPProbe{URL: "http://example.com"},
+				Retries: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "retry_interval without retry_timeout is rejected",
+			probe: Probe{
+				Name: "p", Type: "http", HTTP: &HTTPProbe{URL: "http://example.com"},
+				RetryInterval: time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no retry fields is valid",
+			probe: Probe{
+				Name: "p", Type: "http", HTTP: &HTTPProbe{URL: "http://example.com"},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.probe.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
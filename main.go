@@ -4,16 +4,19 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
+	"gopkg.in/yaml.v3"
+
 	"github.com/gimli-chaos/gimli/domain"
+	"github.com/gimli-chaos/gimli/metrics"
 	"github.com/gimli-chaos/gimli/runner"
 	"github.com/gimli-chaos/gimli/saboteur"
-	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -24,8 +27,11 @@ const (
 func main() {
 	// Parse command line arguments
 	var (
-		configFile = flag.String("config", "experiment.yaml", "Path to experiment configuration file")
-		version    = flag.Bool("version", false, "Show version information")
+		configFile  = flag.String("config", "experiment.yaml", "Path to experiment configuration file")
+		version     = flag.Bool("version", false, "Show version information")
+		logFormat   = flag.String("log-format", "text", "Log output format: json or text")
+		eventsFile  = flag.String("events-file", "", "Path to append newline-delimited JSON events for the experiment")
+		metricsAddr = flag.String("metrics-addr", "", "Address to serve Prometheus /metrics on (e.g. :9090); disabled if empty")
 	)
 	flag.Parse()
 
@@ -34,8 +40,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:       appName,
+		Level:      hclog.Info,
+		JSONFormat: *logFormat == "json",
+	})
+
 	if *configFile == "" {
-		log.Fatal("❌ Configuration file is required")
+		logger.Error("configuration file is required")
+		os.Exit(1)
 	}
 
 	// Create context with cancellation
@@ -47,39 +60,74 @@ func main() {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		log.Println("🛑 Received shutdown signal, cancelling experiment...")
+		logger.Info("received shutdown signal, cancelling experiment")
 		cancel()
 	}()
 
 	// Load and parse configuration
-	log.Printf("📖 Loading configuration from %s", *configFile)
 	experiment, err := loadExperiment(*configFile)
 	if err != nil {
-		log.Fatalf("❌ Failed to load configuration: %v", err)
+		logger.Error("failed to load configuration", "error", err)
+		os.Exit(1)
 	}
 
-	log.Printf("✅ Configuration loaded: %s", experiment.Name)
-
-	// Create saboteur
-	sab, err := saboteur.NewFlySaboteur()
+	// Create saboteur for the backend selected by the experiment
+	selector := experiment.Scenario.Selector
+	sab, err := saboteur.New(selector.Backend, map[string]any{
+		"namespace":  selector.Namespace,
+		"region":     selector.Region,
+		"kubeconfig": selector.Kubeconfig,
+		"host":       selector.Host,
+	})
 	if err != nil {
-		log.Fatalf("❌ Failed to create saboteur: %v", err)
+		logger.Error("failed to create saboteur", "error", err)
+		os.Exit(1)
+	}
+
+	// Start the metrics server before the experiment so a co-located
+	// Prometheus can scrape it mid-run; it stays up until shutdown.
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		metricsServer := &http.Server{Addr: *metricsAddr, Handler: mux}
+
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("metrics server failed", "error", err)
+			}
+		}()
+
+		go func() {
+			<-ctx.Done()
+			metricsServer.Close()
+		}()
+
+		logger.Info("serving metrics", "addr", *metricsAddr)
 	}
 
 	// Create runner
-	runner := runner.NewRunner(sab, log.Default())
+	exprRunner := runner.NewRunner(sab, logger)
+
+	if *eventsFile != "" {
+		eventsOut, err := os.OpenFile(*eventsFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			logger.Error("failed to open events file", "error", err)
+			os.Exit(1)
+		}
+		defer eventsOut.Close()
+
+		exprRunner.SetEventsWriter(eventsOut)
+	}
 
 	// Run experiment
-	log.Println("🚀 Starting chaos engineering experiment...")
 	startTime := time.Now()
 
-	if err := runner.RunExperiment(ctx, experiment); err != nil {
-		duration := time.Since(startTime)
-		log.Fatalf("❌ Experiment failed after %v: %v", duration, err)
+	if err := exprRunner.RunExperiment(ctx, experiment); err != nil {
+		logger.Error("experiment failed", "duration", time.Since(startTime), "error", err)
+		os.Exit(1)
 	}
 
-	duration := time.Since(startTime)
-	log.Printf("🎉 Experiment completed successfully in %v", duration)
+	logger.Info("experiment completed successfully", "duration", time.Since(startTime))
 }
 
 // loadExperiment reads and parses the experiment configuration
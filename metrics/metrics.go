@@ -0,0 +1,55 @@
+// Package metrics exposes Prometheus instrumentation for Gimli experiments
+// so a co-located Prometheus can scrape progress mid-experiment.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// AttacksTotal counts chaos attacks by scenario, app, and result
+	// ("succeeded" or "failed").
+	AttacksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gimli_attacks_total",
+		Help: "Total number of chaos attacks executed, by scenario, app, and result.",
+	}, []string{"scenario", "app", "result"})
+
+	// ProbeDuration records how long each steady-state probe took, by
+	// probe name and result.
+	ProbeDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gimli_probe_duration_seconds",
+		Help:    "Duration of steady-state probe executions, by probe and result.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"probe", "result"})
+
+	// SteadyStateLostTotal counts how many times steady state failed to
+	// hold after an attack.
+	SteadyStateLostTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gimli_steady_state_lost_total",
+		Help: "Total number of times steady state was lost after an attack.",
+	})
+
+	// ExperimentDuration records the total wall-clock time of complete
+	// experiment runs.
+	ExperimentDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "gimli_experiment_duration_seconds",
+		Help:    "Duration of complete experiment runs.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// TargetsEligible tracks how many targets matched the selector for an
+	// app's most recent scenario run, after the blast-radius cap is applied.
+	TargetsEligible = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gimli_targets_eligible",
+		Help: "Number of targets eligible for chaos, by app.",
+	}, []string{"app"})
+)
+
+// Handler returns the HTTP handler that serves the /metrics endpoint.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
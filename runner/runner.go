@@ -2,61 +2,103 @@ package runner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"math/rand"
 	"net/http"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
+
 	"github.com/gimli-chaos/gimli/domain"
+	"github.com/gimli-chaos/gimli/metrics"
 	"github.com/gimli-chaos/gimli/saboteur"
 )
 
 // Runner orchestrates chaos engineering experiments
 type Runner struct {
-	saboteur saboteur.Saboteur
-	logger   *log.Logger
+	saboteur  saboteur.Saboteur
+	logger    hclog.Logger
+	eventsOut io.Writer
 }
 
 // NewRunner creates a new experiment runner
-func NewRunner(saboteur saboteur.Saboteur, logger *log.Logger) *Runner {
+func NewRunner(saboteur saboteur.Saboteur, logger hclog.Logger) *Runner {
 	if logger == nil {
-		logger = log.Default()
+		logger = hclog.Default()
 	}
-	
+
 	return &Runner{
 		saboteur: saboteur,
 		logger:   logger,
 	}
 }
 
+// SetEventsWriter makes the runner append a newline-delimited JSON event to
+// w for every named event (experiment.start, probe.result, ...), in
+// addition to logging it through the configured hclog.Logger. Pass nil to
+// stop writing events.
+func (r *Runner) SetEventsWriter(w io.Writer) {
+	r.eventsOut = w
+}
+
+// emitEvent logs eventType with its key/value fields through the runner's
+// logger and, if an events writer is configured, appends it as a JSON line
+// so experiments are machine-analyzable after the fact.
+func (r *Runner) emitEvent(eventType string, keyvals ...interface{}) {
+	r.logger.Info(eventType, keyvals...)
+
+	if r.eventsOut == nil {
+		return
+	}
+
+	event := map[string]interface{}{
+		"time": time.Now().Format(time.RFC3339Nano),
+		"type": eventType,
+	}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		event[key] = keyvals[i+1]
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	r.eventsOut.Write(append(data, '\n'))
+}
+
 // RunExperiment executes the complete chaos engineering experiment
 func (r *Runner) RunExperiment(ctx context.Context, experiment *domain.Experiment) error {
-	r.logger.Printf("🚀 Starting experiment: %s", experiment.Name)
-	r.logger.Printf("📋 Description: %s", experiment.Description)
-	
+	start := time.Now()
+	defer func() {
+		metrics.ExperimentDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	r.emitEvent("experiment.start", "name", experiment.Name, "description", experiment.Description)
+
 	// Validate steady state before starting
-	r.logger.Println("🔍 Validating steady state...")
 	if err := r.RunProbes(ctx, experiment.SteadyState.Probes); err != nil {
 		return fmt.Errorf("steady state validation failed: %w", err)
 	}
-	r.logger.Println("✅ Steady state validated")
-	
+
 	// Execute the chaos scenario
 	if err := r.executeScenario(ctx, experiment); err != nil {
 		return fmt.Errorf("scenario execution failed: %w", err)
 	}
-	
+
 	// Validate steady state after chaos
-	r.logger.Println("🔍 Validating steady state after chaos...")
 	if err := r.RunProbes(ctx, experiment.SteadyState.Probes); err != nil {
 		return fmt.Errorf("steady state validation failed after chaos: %w", err)
 	}
-	r.logger.Println("✅ Steady state maintained after chaos")
-	
-	r.logger.Println("🎉 Experiment completed successfully")
+
+	r.emitEvent("experiment.complete", "name", experiment.Name, "duration_ms", time.Since(start).Milliseconds())
 	return nil
 }
 
@@ -69,11 +111,15 @@ func (r *Runner) RunProbes(ctx context.Context, probes []domain.Probe) error {
 		wg.Add(1)
 		go func(p domain.Probe) {
 			defer wg.Done()
-			
+
+			start := time.Now()
 			if err := r.executeProbe(ctx, p); err != nil {
+				metrics.ProbeDuration.WithLabelValues(p.Name, "failed").Observe(time.Since(start).Seconds())
+				r.emitEvent("probe.result", "probe_name", p.Name, "status", "failed", "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
 				errors <- fmt.Errorf("probe '%s' failed: %w", p.Name, err)
 			} else {
-				r.logger.Printf("✅ Probe '%s' passed", p.Name)
+				metrics.ProbeDuration.WithLabelValues(p.Name, "passed").Observe(time.Since(start).Seconds())
+				r.emitEvent("probe.result", "probe_name", p.Name, "status", "passed", "duration_ms", time.Since(start).Milliseconds())
 			}
 		}(probe)
 	}
@@ -94,120 +140,368 @@ func (r *Runner) RunProbes(ctx context.Context, probes []domain.Probe) error {
 	return nil
 }
 
-// executeProbe runs a single probe
+// executeProbe runs a probe, retrying on failure until it passes or
+// probe.RetryTimeout elapses. With RetryTimeout unset the probe fires once,
+// matching the original single-shot behavior.
 func (r *Runner) executeProbe(ctx context.Context, probe domain.Probe) error {
 	if probe.Type != "http" {
 		return fmt.Errorf("unsupported probe type: %s", probe.Type)
 	}
-	
-	httpProbe := probe.HTTP
-	if httpProbe == nil {
+
+	if probe.HTTP == nil {
 		return fmt.Errorf("HTTP probe configuration is missing")
 	}
-	
-	// Create HTTP request
+
+	client := &http.Client{Timeout: probe.Timeout}
+
+	deadline := time.Now().Add(probe.RetryTimeout)
+	interval := probe.RetryInterval
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		lastErr = doHTTPProbe(ctx, client, probe.HTTP)
+		if lastErr == nil {
+			return nil
+		}
+
+		if probe.RetryTimeout == 0 || time.Now().After(deadline) {
+			return lastErr
+		}
+		if probe.Retries > 0 && attempt >= probe.Retries {
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if probe.RetryBackoff == "exponential" {
+			interval *= 2
+			if probe.MaxInterval > 0 && interval > probe.MaxInterval {
+				interval = probe.MaxInterval
+			}
+		}
+	}
+}
+
+// doHTTPProbe executes a single HTTP probe attempt
+func doHTTPProbe(ctx context.Context, client *http.Client, httpProbe *domain.HTTPProbe) error {
 	req, err := http.NewRequestWithContext(ctx, httpProbe.Method, httpProbe.URL, nil)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
-	
-	// Add headers
+
 	for key, value := range httpProbe.Headers {
 		req.Header.Set(key, value)
 	}
-	
-	// Execute request with timeout
-	client := &http.Client{
-		Timeout: probe.Timeout,
-	}
-	
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
-	
+
 	// Consume response body to ensure connection reuse
 	io.Copy(io.Discard, resp.Body)
-	
-	// Check status code
+
 	if resp.StatusCode != httpProbe.ExpectedStatus {
 		return fmt.Errorf("unexpected status code: got %d, want %d", resp.StatusCode, httpProbe.ExpectedStatus)
 	}
-	
+
 	return nil
 }
 
 // executeScenario runs the chaos scenario
 func (r *Runner) executeScenario(ctx context.Context, experiment *domain.Experiment) error {
 	scenario := experiment.Scenario
-	r.logger.Printf("🔥 Executing chaos scenario: %s for %v (interval: %v)",
-		scenario.Type, scenario.Duration, scenario.Interval)
-	
+
 	// Get targets for chaos
-	targets, err := r.saboteur.ListTargets(ctx, scenario.Selector.App, nil)
+	targets, err := r.saboteur.ListTargets(ctx, scenario.Selector.App, targetSelectorFrom(scenario.Selector))
 	if err != nil {
 		return fmt.Errorf("listing targets: %w", err)
 	}
-	
-	r.logger.Printf("🎯 Found %d eligible targets", len(targets))
-	
+
+	targets = applyBlastRadiusCap(targets, scenario.MaxPercentage, scenario.MaxCount)
+
+	metrics.TargetsEligible.WithLabelValues(scenario.Selector.App).Set(float64(len(targets)))
+	r.emitEvent("targets.eligible", "app", scenario.Selector.App, "count", len(targets))
+
 	// Create ticker for interval-based chaos
 	ticker := time.NewTicker(scenario.Interval)
 	defer ticker.Stop()
-	
+
 	// Create timeout for total duration
 	durationCtx, cancel := context.WithTimeout(ctx, scenario.Duration)
 	defer cancel()
-	
+
 	attackCount := 0
-	
+	var history []saboteur.AttackRecord
+	consecutiveFailures := 0
+	totalFailures := 0
+
 	for {
 		select {
 		case <-durationCtx.Done():
-			r.logger.Printf("⏰ Scenario duration completed. Attacks executed: %d", attackCount)
+			r.logger.Info("scenario duration completed", "attacks_executed", attackCount)
 			return nil
-			
+
 		case <-ticker.C:
+			if len(targets) == 0 {
+				r.logger.Warn("no live targets remain, skipping attack", "app", scenario.Selector.App)
+				continue
+			}
+
 			// Execute attack
-			if err := r.executeAttack(durationCtx, scenario, targets); err != nil {
-				r.logger.Printf("⚠️  Attack failed: %v", err)
+			start := time.Now()
+			records, remaining, err := r.executeAttack(durationCtx, scenario, targets)
+			history = append(history, records...)
+			targets = remaining
+			if err != nil {
+				metrics.AttacksTotal.WithLabelValues(experiment.Name, scenario.Selector.App, "failed").Inc()
+				r.emitEvent("attack.executed", "app", scenario.Selector.App, "status", "failed", "duration_ms", time.Since(start).Milliseconds(), "error", err.Error())
 				continue
 			}
 			attackCount++
-			
+			metrics.AttacksTotal.WithLabelValues(experiment.Name, scenario.Selector.App, "succeeded").Inc()
+			r.emitEvent("attack.executed", "app", scenario.Selector.App, "status", "succeeded", "duration_ms", time.Since(start).Milliseconds(), "attempt", attackCount)
+
 			// Validate steady state after attack
-			r.logger.Println("🔍 Validating steady state after attack...")
 			if err := r.RunProbes(durationCtx, experiment.SteadyState.Probes); err != nil {
-				return fmt.Errorf("steady state lost after attack: %w", err)
+				metrics.SteadyStateLostTotal.Inc()
+				consecutiveFailures++
+				totalFailures++
+				r.emitEvent("steady_state.lost", "app", scenario.Selector.App, "attempt", attackCount, "consecutive_failures", consecutiveFailures, "total_failures", totalFailures)
+
+				if !safetyBudgetExceeded(experiment.Safety, consecutiveFailures, totalFailures) {
+					continue
+				}
+
+				return r.rollback(ctx, scenario.Selector.App, history, experiment.SteadyState.Probes,
+					fmt.Errorf("steady state lost after attack: %w", err))
 			}
+
+			consecutiveFailures = 0
 		}
 	}
 }
 
-// executeAttack performs a single chaos attack
-func (r *Runner) executeAttack(ctx context.Context, scenario domain.Scenario, targets []saboteur.Target) error {
-	if scenario.Type != "restart_random" {
-		return fmt.Errorf("unsupported scenario type: %s", scenario.Type)
+// safetyBudgetExceeded reports whether the experiment's safety budget has
+// been breached and the runner should halt and roll back. With no budget
+// configured, it breaches on the very first failure, matching the
+// original behavior of aborting immediately.
+func safetyBudgetExceeded(safety domain.Safety, consecutiveFailures, totalFailures int) bool {
+	if safety.AbortOnProbeFailure {
+		return true
 	}
-	
-	// Select random target
+
+	if safety.MaxConsecutiveFailures == 0 && safety.MaxTotalFailures == 0 {
+		return true
+	}
+
+	if safety.MaxConsecutiveFailures > 0 && consecutiveFailures >= safety.MaxConsecutiveFailures {
+		return true
+	}
+
+	if safety.MaxTotalFailures > 0 && totalFailures >= safety.MaxTotalFailures {
+		return true
+	}
+
+	return false
+}
+
+// rollback asks the saboteur to undo the recorded attacks, then re-runs
+// probes once to confirm recovery before reporting the original failure.
+func (r *Runner) rollback(ctx context.Context, app string, history []saboteur.AttackRecord, probes []domain.Probe, cause error) error {
+	r.emitEvent("rollback.start", "app", app, "attacks", len(history))
+
+	if err := r.saboteur.Rollback(ctx, app, history); err != nil {
+		return fmt.Errorf("safety budget exceeded (%w); rollback failed: %v", cause, err)
+	}
+
+	if err := r.RunProbes(ctx, probes); err != nil {
+		return fmt.Errorf("safety budget exceeded (%w); rollback did not restore steady state: %w", cause, err)
+	}
+
+	r.emitEvent("rollback.complete", "app", app)
+
+	return fmt.Errorf("safety budget exceeded, experiment aborted and rolled back: %w", cause)
+}
+
+// executeAttack runs every configured action once against the target pool,
+// returning a record of every target touched so it can be rolled back if
+// the experiment's safety budget is later exceeded, along with the targets
+// that are still live. Actions that take a target down (kill_random,
+// stop_percentage) remove it from the pool before the next action in the
+// same tick picks a target, so a later action or a later tick never draws
+// an already-stopped target.
+func (r *Runner) executeAttack(ctx context.Context, scenario domain.Scenario, targets []saboteur.Target) ([]saboteur.AttackRecord, []saboteur.Target, error) {
 	if len(targets) == 0 {
-		return fmt.Errorf("no targets available")
+		return nil, targets, fmt.Errorf("no targets available")
 	}
-	
-	target := targets[rand.Intn(len(targets))]
-	
-	r.logger.Printf("💥 Attacking machine %s (%s)...", target.ID, target.Name)
-	
-	if err := r.saboteur.RestartMachine(ctx, scenario.Selector.App, target.ID); err != nil {
-		return fmt.Errorf("restarting machine %s: %w", target.ID, err)
+
+	var history []saboteur.AttackRecord
+	for _, action := range scenario.Actions {
+		if len(targets) == 0 {
+			return history, targets, fmt.Errorf("action %s: no targets available", action.Type)
+		}
+
+		records, remaining, err := r.executeAction(ctx, scenario.Selector.App, action, targets)
+		history = append(history, records...)
+		targets = remaining
+		if err != nil {
+			return history, targets, fmt.Errorf("action %s: %w", action.Type, err)
+		}
 	}
-	
-	r.logger.Printf("✅ Successfully attacked machine %s", target.ID)
-	
-	// Wait a moment for the restart to take effect
+
+	// Wait a moment for the actions to take effect
 	time.Sleep(2 * time.Second)
-	
-	return nil
+
+	return history, targets, nil
+}
+
+// executeAction performs a single chaos action against the target pool,
+// returning a record for every target it touched and the targets that
+// remain live. Actions that stop a target drop it from the returned pool;
+// every other action returns the pool unchanged.
+func (r *Runner) executeAction(ctx context.Context, app string, action domain.ScenarioAction, targets []saboteur.Target) ([]saboteur.AttackRecord, []saboteur.Target, error) {
+	now := time.Now()
+
+	switch action.Type {
+	case "restart_random":
+		target := randomTarget(targets)
+		r.logger.Debug("restarting machine", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		return []saboteur.AttackRecord{record}, targets, r.saboteur.RestartMachine(ctx, app, target.ID)
+
+	case "kill_random":
+		target := randomTarget(targets)
+		r.logger.Debug("killing machine", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		if err := r.saboteur.StopMachine(ctx, app, target.ID); err != nil {
+			return []saboteur.AttackRecord{record}, targets, err
+		}
+		return []saboteur.AttackRecord{record}, removeTarget(targets, target.ID), nil
+
+	case "stop_percentage":
+		var records []saboteur.AttackRecord
+		remaining := targets
+		for _, target := range percentageOfTargets(targets, action.Percentage) {
+			r.logger.Debug("stopping machine", "target_id", target.ID, "target_name", target.Name)
+			records = append(records, saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now})
+			if err := r.saboteur.StopMachine(ctx, app, target.ID); err != nil {
+				return records, remaining, fmt.Errorf("stopping machine %s: %w", target.ID, err)
+			}
+			remaining = removeTarget(remaining, target.ID)
+		}
+		return records, remaining, nil
+
+	case "inject_latency":
+		target := randomTarget(targets)
+		r.logger.Debug("injecting latency", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		return []saboteur.AttackRecord{record}, targets, r.saboteur.InjectLatency(ctx, app, target.ID, *action.Latency)
+
+	case "cpu_stress":
+		target := randomTarget(targets)
+		r.logger.Debug("stressing cpu", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		return []saboteur.AttackRecord{record}, targets, r.saboteur.StressCPU(ctx, app, target.ID, *action.CPU)
+
+	case "memory_stress":
+		target := randomTarget(targets)
+		r.logger.Debug("stressing memory", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		return []saboteur.AttackRecord{record}, targets, r.saboteur.StressMemory(ctx, app, target.ID, *action.Memory)
+
+	case "network_partition":
+		target := randomTarget(targets)
+		r.logger.Debug("partitioning machine", "target_id", target.ID, "target_name", target.Name)
+		record := saboteur.AttackRecord{TargetID: target.ID, Action: action.Type, Timestamp: now}
+		return []saboteur.AttackRecord{record}, targets, r.saboteur.Partition(ctx, app, target.ID)
+
+	default:
+		return nil, targets, fmt.Errorf("unsupported action type: %s", action.Type)
+	}
+}
+
+// removeTarget returns a copy of targets with id excluded, used to drop a
+// target from the working pool once it has been stopped.
+func removeTarget(targets []saboteur.Target, id string) []saboteur.Target {
+	remaining := make([]saboteur.Target, 0, len(targets))
+	for _, t := range targets {
+		if t.ID != id {
+			remaining = append(remaining, t)
+		}
+	}
+	return remaining
+}
+
+// targetSelectorFrom converts a domain.Selector into the backend-agnostic
+// saboteur.TargetSelector, merging the Kubernetes-style Labels and the
+// EC2-style Tags into a single label match set.
+func targetSelectorFrom(selector domain.Selector) saboteur.TargetSelector {
+	labels := make(map[string]string, len(selector.Labels)+len(selector.Tags))
+	for k, v := range selector.Labels {
+		labels[k] = v
+	}
+	for k, v := range selector.Tags {
+		labels[k] = v
+	}
+
+	return saboteur.TargetSelector{Labels: labels, Regions: selector.Regions}
+}
+
+// applyBlastRadiusCap trims targets down to the experiment's configured
+// blast-radius limit, picking a random subset so repeated runs don't always
+// hit the same machines. A cap of 0 for both arguments leaves targets
+// untouched.
+func applyBlastRadiusCap(targets []saboteur.Target, maxPercentage, maxCount int) []saboteur.Target {
+	limit := len(targets)
+	switch {
+	case maxCount > 0:
+		limit = maxCount
+	case maxPercentage > 0:
+		limit = len(targets) * maxPercentage / 100
+		if limit < 1 {
+			limit = 1
+		}
+	default:
+		return targets
+	}
+
+	if limit >= len(targets) {
+		return targets
+	}
+
+	shuffled := make([]saboteur.Target, len(targets))
+	copy(shuffled, targets)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:limit]
+}
+
+// randomTarget picks a single target at random from the pool
+func randomTarget(targets []saboteur.Target) saboteur.Target {
+	return targets[rand.Intn(len(targets))]
+}
+
+// percentageOfTargets returns a random subset of pct percent of the target
+// pool, rounded down, always including at least one target
+func percentageOfTargets(targets []saboteur.Target, pct int) []saboteur.Target {
+	count := len(targets) * pct / 100
+	if count < 1 {
+		count = 1
+	}
+
+	shuffled := make([]saboteur.Target, len(targets))
+	copy(shuffled, targets)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return shuffled[:count]
 }
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"testing"
+
+	"github.com/gimli-chaos/gimli/domain"
+)
+
+func TestSafetyBudgetExceeded(t *testing.T) {
+	tests := []struct {
+		name                string
+		safety              domain.Safety
+		consecutiveFailures int
+		totalFailures       int
+		want                bool
+	}{
+		{
+			name:                "no budget configured breaches on first failure",
+			safety:              domain.Safety{},
+			consecutiveFailures: 1,
+			totalFailures:       1,
+			want:                true,
+		},
+		{
+			name:                "abort_on_probe_failure always breaches",
+			safety:              domain.Safety{AbortOnProbeFailure: true, MaxConsecutiveFailures: 10},
+			consecutiveFailures: 1,
+			totalFailures:       1,
+			want:                true,
+		},
+		{
+			name:                "under both budgets does not breach",
+			safety:              domain.Safety{MaxConsecutiveFailures: 3, MaxTotalFailures: 5},
+			consecutiveFailures: 2,
+			totalFailures:       2,
+			want:                false,
+		},
+		{
+			name:                "consecutive failures at limit breaches",
+			safety:              domain.Safety{MaxConsecutiveFailures: 3, MaxTotalFailures: 5},
+			consecutiveFailures: 3,
+			totalFailures:       3,
+			want:                true,
+		},
+		{
+			name:                "total failures at limit breaches even with consecutive reset",
+			safety:              domain.Safety{MaxConsecutiveFailures: 3, MaxTotalFailures: 5},
+			consecutiveFailures: 1,
+			totalFailures:       5,
+			want:                true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := safetyBudgetExceeded(tt.safety, tt.consecutiveFailures, tt.totalFailures)
+			if got != tt.want {
+				t.Errorf("safetyBudgetExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
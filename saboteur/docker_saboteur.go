@@ -0,0 +1,146 @@
+package saboteur
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+
+	"github.com/gimli-chaos/gimli/domain"
+)
+
+func init() {
+	Register("docker", NewDockerSaboteurFromConfig)
+}
+
+// DockerSaboteur implements the Saboteur interface by killing and
+// restarting containers on a local or remote Docker daemon.
+type DockerSaboteur struct {
+	client *client.Client
+}
+
+// NewDockerSaboteurFromConfig builds a DockerSaboteur from the registry
+// configuration map. Recognized keys: "host" (Docker daemon host; defaults
+// to the environment via client.FromEnv).
+func NewDockerSaboteurFromConfig(cfg map[string]any) (Saboteur, error) {
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if host, _ := cfg["host"].(string); host != "" {
+		opts = append(opts, client.WithHost(host))
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating docker client: %w", err)
+	}
+
+	return &DockerSaboteur{client: cli}, nil
+}
+
+// ListTargets returns running containers labeled app=<app>, further
+// filtered by selector.
+func (d *DockerSaboteur) ListTargets(ctx context.Context, app string, selector TargetSelector) ([]Target, error) {
+	containers, err := d.client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("app=%s", app))),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing containers: %w", err)
+	}
+
+	var targets []Target
+	for _, c := range containers {
+		if c.State != "running" {
+			continue
+		}
+
+		// Docker containers have no region concept, so a selector with a
+		// non-empty Regions matches no container.
+		if !selector.matches(c.Labels, "") {
+			continue
+		}
+
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = c.Names[0]
+		}
+
+		targets = append(targets, Target{
+			ID:       c.ID,
+			Name:     name,
+			State:    c.State,
+			Metadata: c.Labels,
+		})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no eligible targets found for app %s", app)
+	}
+
+	return targets, nil
+}
+
+// RestartMachine restarts the named Docker container.
+func (d *DockerSaboteur) RestartMachine(ctx context.Context, app string, machineID string) error {
+	if err := d.client.ContainerRestart(ctx, machineID, container.StopOptions{}); err != nil {
+		return fmt.Errorf("restarting container %s: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// StopMachine kills the named Docker container.
+func (d *DockerSaboteur) StopMachine(ctx context.Context, app string, machineID string) error {
+	if err := d.client.ContainerKill(ctx, machineID, "SIGKILL"); err != nil {
+		return fmt.Errorf("killing container %s: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// InjectLatency is not yet supported on the docker backend.
+func (d *DockerSaboteur) InjectLatency(ctx context.Context, app string, machineID string, latency domain.LatencyAction) error {
+	return fmt.Errorf("docker backend does not support inject_latency")
+}
+
+// StressCPU is not yet supported on the docker backend.
+func (d *DockerSaboteur) StressCPU(ctx context.Context, app string, machineID string, stress domain.CPUStressAction) error {
+	return fmt.Errorf("docker backend does not support cpu_stress")
+}
+
+// StressMemory is not yet supported on the docker backend.
+func (d *DockerSaboteur) StressMemory(ctx context.Context, app string, machineID string, stress domain.MemoryStressAction) error {
+	return fmt.Errorf("docker backend does not support memory_stress")
+}
+
+// Partition is not yet supported on the docker backend.
+func (d *DockerSaboteur) Partition(ctx context.Context, app string, machineID string) error {
+	return fmt.Errorf("docker backend does not support network_partition")
+}
+
+// Rollback restarts any container that was killed, based on the actions
+// recorded in history.
+func (d *DockerSaboteur) Rollback(ctx context.Context, app string, history []AttackRecord) error {
+	var errs []error
+	restarted := make(map[string]bool)
+
+	for _, record := range history {
+		if record.Action != "kill_random" && record.Action != "stop_percentage" {
+			continue
+		}
+		if restarted[record.TargetID] {
+			continue
+		}
+		restarted[record.TargetID] = true
+
+		if err := d.client.ContainerStart(ctx, record.TargetID, container.StartOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("starting container %s: %w", record.TargetID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback encountered %d error(s): %v", len(errs), errs)
+	}
+
+	return nil
+}
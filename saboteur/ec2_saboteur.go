@@ -0,0 +1,173 @@
+package saboteur
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/gimli-chaos/gimli/domain"
+)
+
+func init() {
+	Register("ec2", NewEC2SaboteurFromConfig)
+}
+
+// EC2Saboteur implements the Saboteur interface by rebooting AWS EC2
+// instances.
+type EC2Saboteur struct {
+	client *ec2.Client
+}
+
+// NewEC2SaboteurFromConfig builds an EC2Saboteur from the registry
+// configuration map. Recognized keys: "region" (AWS region override; falls
+// back to the default AWS credential chain when empty).
+func NewEC2SaboteurFromConfig(cfg map[string]any) (Saboteur, error) {
+	ctx := context.Background()
+
+	var optFns []func(*config.LoadOptions) error
+	if region, _ := cfg["region"].(string); region != "" {
+		optFns = append(optFns, config.WithRegion(region))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	return &EC2Saboteur{client: ec2.NewFromConfig(awsCfg)}, nil
+}
+
+// ListTargets returns running EC2 instances tagged app=<app>, further
+// filtered by selector against the instance's tags.
+func (e *EC2Saboteur) ListTargets(ctx context.Context, app string, selector TargetSelector) ([]Target, error) {
+	out, err := e.client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("tag:app"), Values: []string{app}},
+			{Name: aws.String("instance-state-name"), Values: []string{"running"}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing instances: %w", err)
+	}
+
+	var targets []Target
+	for _, reservation := range out.Reservations {
+		for _, instance := range reservation.Instances {
+			tags := make(map[string]string, len(instance.Tags))
+			for _, tag := range instance.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+
+			region := regionFromAvailabilityZone(aws.ToString(instance.Placement.AvailabilityZone))
+			if !selector.matches(tags, region) {
+				continue
+			}
+
+			targets = append(targets, Target{
+				ID:       aws.ToString(instance.InstanceId),
+				Name:     tags["Name"],
+				State:    string(instance.State.Name),
+				Region:   region,
+				Metadata: tags,
+			})
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no eligible targets found for app %s", app)
+	}
+
+	return targets, nil
+}
+
+// regionFromAvailabilityZone strips an availability zone's single-letter
+// suffix (e.g. "us-east-1a") down to its region (e.g. "us-east-1"), so
+// Selector.Regions can be matched against the region a user would
+// actually write in an experiment's config, consistent with the Fly
+// backend's region semantics.
+func regionFromAvailabilityZone(az string) string {
+	if az == "" {
+		return az
+	}
+
+	last := az[len(az)-1]
+	if last < 'a' || last > 'z' {
+		return az
+	}
+
+	return az[:len(az)-1]
+}
+
+// RestartMachine reboots the named EC2 instance.
+func (e *EC2Saboteur) RestartMachine(ctx context.Context, app string, machineID string) error {
+	if _, err := e.client.RebootInstances(ctx, &ec2.RebootInstancesInput{
+		InstanceIds: []string{machineID},
+	}); err != nil {
+		return fmt.Errorf("rebooting instance %s: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// StopMachine stops the named EC2 instance.
+func (e *EC2Saboteur) StopMachine(ctx context.Context, app string, machineID string) error {
+	if _, err := e.client.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{machineID},
+	}); err != nil {
+		return fmt.Errorf("stopping instance %s: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// InjectLatency is not yet supported on the ec2 backend.
+func (e *EC2Saboteur) InjectLatency(ctx context.Context, app string, machineID string, latency domain.LatencyAction) error {
+	return fmt.Errorf("ec2 backend does not support inject_latency")
+}
+
+// StressCPU is not yet supported on the ec2 backend.
+func (e *EC2Saboteur) StressCPU(ctx context.Context, app string, machineID string, stress domain.CPUStressAction) error {
+	return fmt.Errorf("ec2 backend does not support cpu_stress")
+}
+
+// StressMemory is not yet supported on the ec2 backend.
+func (e *EC2Saboteur) StressMemory(ctx context.Context, app string, machineID string, stress domain.MemoryStressAction) error {
+	return fmt.Errorf("ec2 backend does not support memory_stress")
+}
+
+// Partition is not yet supported on the ec2 backend.
+func (e *EC2Saboteur) Partition(ctx context.Context, app string, machineID string) error {
+	return fmt.Errorf("ec2 backend does not support network_partition")
+}
+
+// Rollback starts any instance that was stopped, based on the actions
+// recorded in history.
+func (e *EC2Saboteur) Rollback(ctx context.Context, app string, history []AttackRecord) error {
+	var instanceIDs []string
+	started := make(map[string]bool)
+
+	for _, record := range history {
+		if record.Action != "kill_random" && record.Action != "stop_percentage" {
+			continue
+		}
+		if started[record.TargetID] {
+			continue
+		}
+		started[record.TargetID] = true
+		instanceIDs = append(instanceIDs, record.TargetID)
+	}
+
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	if _, err := e.client.StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: instanceIDs}); err != nil {
+		return fmt.Errorf("starting instances: %w", err)
+	}
+
+	return nil
+}
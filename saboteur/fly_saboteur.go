@@ -1,6 +1,7 @@
 package saboteur
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,15 +9,42 @@ import (
 	"net/http"
 	"os"
 	"time"
+
+	"github.com/gimli-chaos/gimli/domain"
 )
 
-// Saboteur interface defines the contract for chaos operations
+// Saboteur interface defines the contract for chaos operations. Each
+// backend (Fly.io, Kubernetes, Docker, EC2, ...) registers an implementation
+// via Register and is selected at runtime through New. A backend that
+// cannot support a given action should return an error from it rather than
+// silently no-op.
 type Saboteur interface {
-	ListTargets(ctx context.Context, app string, selector map[string]string) ([]Target, error)
+	ListTargets(ctx context.Context, app string, selector TargetSelector) ([]Target, error)
 	RestartMachine(ctx context.Context, app string, machineID string) error
+	StopMachine(ctx context.Context, app string, machineID string) error
+	InjectLatency(ctx context.Context, app string, machineID string, latency domain.LatencyAction) error
+	StressCPU(ctx context.Context, app string, machineID string, stress domain.CPUStressAction) error
+	StressMemory(ctx context.Context, app string, machineID string, stress domain.MemoryStressAction) error
+	Partition(ctx context.Context, app string, machineID string) error
+
+	// Rollback attempts to undo the effects of history, e.g. restarting
+	// stopped machines and clearing injected latency/stress, so the
+	// system returns to its pre-experiment state after a safety-budget
+	// breach.
+	Rollback(ctx context.Context, app string, history []AttackRecord) error
+}
+
+// AttackRecord is one entry in the history of attacks executed during an
+// experiment. Rollback uses it to know what to undo.
+type AttackRecord struct {
+	TargetID  string
+	Action    string
+	Timestamp time.Time
 }
 
-// Target represents a Fly.io machine that can be targeted for chaos
+// Target represents a backend-specific resource (a Fly.io machine, a
+// Kubernetes pod, a Docker container, an EC2 instance, ...) that can be
+// targeted for chaos
 type Target struct {
 	ID       string            `json:"id"`
 	Name     string            `json:"name"`
@@ -32,6 +60,12 @@ type FlySaboteur struct {
 	baseURL    string
 }
 
+func init() {
+	Register("fly", func(cfg map[string]any) (Saboteur, error) {
+		return NewFlySaboteur()
+	})
+}
+
 // NewFlySaboteur creates a new Fly.io saboteur instance
 func NewFlySaboteur() (*FlySaboteur, error) {
 	apiToken := os.Getenv("FLY_API_TOKEN")
@@ -49,7 +83,7 @@ func NewFlySaboteur() (*FlySaboteur, error) {
 }
 
 // ListTargets fetches and filters machines from Fly.io
-func (f *FlySaboteur) ListTargets(ctx context.Context, app string, selector map[string]string) ([]Target, error) {
+func (f *FlySaboteur) ListTargets(ctx context.Context, app string, selector TargetSelector) ([]Target, error) {
 	url := fmt.Sprintf("%s/apps/%s/machines", f.baseURL, app)
 	
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -72,16 +106,19 @@ func (f *FlySaboteur) ListTargets(ctx context.Context, app string, selector map[
 	}
 	
 	var machines []struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		State string `json:"state"`
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		State  string `json:"state"`
 		Region string `json:"region"`
+		Config struct {
+			Metadata map[string]string `json:"metadata"`
+		} `json:"config"`
 	}
-	
+
 	if err := json.NewDecoder(resp.Body).Decode(&machines); err != nil {
 		return nil, fmt.Errorf("decoding response: %w", err)
 	}
-	
+
 	// Convert to targets and filter by selector
 	var targets []Target
 	for _, machine := range machines {
@@ -89,16 +126,18 @@ func (f *FlySaboteur) ListTargets(ctx context.Context, app string, selector map[
 		if machine.State != "started" && machine.State != "running" {
 			continue
 		}
-		
-		target := Target{
-			ID:     machine.ID,
-			Name:   machine.Name,
-			State:  machine.State,
-			Region: machine.Region,
+
+		if !selector.matches(machine.Config.Metadata, machine.Region) {
+			continue
 		}
-		
-		// Apply selector filters (simplified - in real implementation would match labels)
-		targets = append(targets, target)
+
+		targets = append(targets, Target{
+			ID:       machine.ID,
+			Name:     machine.Name,
+			State:    machine.State,
+			Region:   machine.Region,
+			Metadata: machine.Config.Metadata,
+		})
 	}
 	
 	if len(targets) == 0 {
@@ -130,6 +169,160 @@ func (f *FlySaboteur) RestartMachine(ctx context.Context, app string, machineID
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
 	}
-	
+
+	return nil
+}
+
+// StopMachine stops a specific Fly.io machine without restarting it.
+func (f *FlySaboteur) StopMachine(ctx context.Context, app string, machineID string) error {
+	url := fmt.Sprintf("%s/apps/%s/machines/%s/stop", f.baseURL, app, machineID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.apiToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// InjectLatency adds network latency on the target machine by execing tc
+// (via netem) inside it over the Fly machines exec API.
+func (f *FlySaboteur) InjectLatency(ctx context.Context, app string, machineID string, latency domain.LatencyAction) error {
+	cmd := []string{"tc", "qdisc", "add", "dev", "eth0", "root", "netem",
+		"delay", fmt.Sprintf("%dms", latency.DurationMS), fmt.Sprintf("%dms", latency.JitterMS)}
+	if latency.Port != 0 {
+		cmd = append(cmd, "port", fmt.Sprintf("%d", latency.Port))
+	}
+
+	return f.exec(ctx, app, machineID, cmd)
+}
+
+// StressCPU pegs the given number of cores on the target machine for
+// Duration by execing stress-ng inside it over the Fly machines exec API.
+func (f *FlySaboteur) StressCPU(ctx context.Context, app string, machineID string, stress domain.CPUStressAction) error {
+	cmd := []string{"stress-ng", "--cpu", fmt.Sprintf("%d", stress.Cores), "--timeout", stress.Duration.String()}
+	return f.exec(ctx, app, machineID, cmd)
+}
+
+// StressMemory consumes the given amount of memory on the target machine
+// for Duration by execing stress-ng inside it over the Fly machines exec
+// API.
+func (f *FlySaboteur) StressMemory(ctx context.Context, app string, machineID string, stress domain.MemoryStressAction) error {
+	cmd := []string{"stress-ng", "--vm", "1", "--vm-bytes", fmt.Sprintf("%dM", stress.MB), "--timeout", stress.Duration.String()}
+	return f.exec(ctx, app, machineID, cmd)
+}
+
+// Partition isolates the target machine from the network by execing tc to
+// drop all traffic on its primary interface.
+func (f *FlySaboteur) Partition(ctx context.Context, app string, machineID string) error {
+	return f.exec(ctx, app, machineID, []string{"tc", "qdisc", "add", "dev", "eth0", "root", "netem", "loss", "100%"})
+}
+
+// Rollback restarts any machine that was stopped or killed and clears any
+// injected latency/stress, based on the actions recorded in history.
+func (f *FlySaboteur) Rollback(ctx context.Context, app string, history []AttackRecord) error {
+	var errs []error
+	handled := make(map[string]bool)
+
+	for _, record := range history {
+		dedupeKey := record.TargetID + ":" + record.Action
+		if handled[dedupeKey] {
+			continue
+		}
+		handled[dedupeKey] = true
+
+		var err error
+		switch record.Action {
+		case "kill_random", "stop_percentage":
+			err = f.startMachine(ctx, app, record.TargetID)
+		case "inject_latency", "network_partition":
+			err = f.exec(ctx, app, record.TargetID, []string{"tc", "qdisc", "del", "dev", "eth0", "root"})
+		case "cpu_stress", "memory_stress":
+			err = f.exec(ctx, app, record.TargetID, []string{"pkill", "stress-ng"})
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rolling back %s on machine %s: %w", record.Action, record.TargetID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rollback encountered %d error(s): %v", len(errs), errs)
+	}
+
+	return nil
+}
+
+// startMachine starts a previously stopped Fly.io machine.
+func (f *FlySaboteur) startMachine(ctx context.Context, app string, machineID string) error {
+	url := fmt.Sprintf("%s/apps/%s/machines/%s/start", f.baseURL, app, machineID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.apiToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// exec runs a command inside a running machine via the Fly machines exec
+// API.
+func (f *FlySaboteur) exec(ctx context.Context, app string, machineID string, cmd []string) error {
+	url := fmt.Sprintf("%s/apps/%s/machines/%s/exec", f.baseURL, app, machineID)
+
+	payload, err := json.Marshal(struct {
+		Cmd []string `json:"cmd"`
+	}{Cmd: cmd})
+	if err != nil {
+		return fmt.Errorf("encoding exec payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", f.apiToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+
 	return nil
 }
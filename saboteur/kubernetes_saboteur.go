@@ -0,0 +1,227 @@
+package saboteur
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gimli-chaos/gimli/domain"
+)
+
+func init() {
+	Register("kubernetes", NewKubernetesSaboteurFromConfig)
+}
+
+// defaultDebugImage is the ephemeral container image used to run tc and
+// stress-ng against a target pod. Stock alpine does not bundle either
+// binary, so this must be an image that does; operators can point at
+// their own via the "debug_image" config key.
+const defaultDebugImage = "ghcr.io/gimli-chaos/toolbox:latest"
+
+// KubernetesSaboteur implements the Saboteur interface by deleting pods in
+// a Kubernetes cluster, relying on their controller to reschedule a
+// replacement.
+type KubernetesSaboteur struct {
+	clientset  *kubernetes.Clientset
+	namespace  string
+	debugImage string
+}
+
+// NewKubernetesSaboteurFromConfig builds a KubernetesSaboteur from the
+// registry configuration map. Recognized keys: "kubeconfig" (path to a
+// kubeconfig file; falls back to in-cluster config when empty), "namespace"
+// (defaults to "default"), and "debug_image" (the ephemeral container
+// image used for inject_latency/cpu_stress/memory_stress/network_partition;
+// must bundle iproute2 and stress-ng, defaults to defaultDebugImage).
+func NewKubernetesSaboteurFromConfig(cfg map[string]any) (Saboteur, error) {
+	namespace, _ := cfg["namespace"].(string)
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	debugImage, _ := cfg["debug_image"].(string)
+	if debugImage == "" {
+		debugImage = defaultDebugImage
+	}
+
+	restConfig, err := kubernetesRESTConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating kubernetes client: %w", err)
+	}
+
+	return &KubernetesSaboteur{clientset: clientset, namespace: namespace, debugImage: debugImage}, nil
+}
+
+func kubernetesRESTConfig(cfg map[string]any) (*rest.Config, error) {
+	if kubeconfig, _ := cfg["kubeconfig"].(string); kubeconfig != "" {
+		return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+
+	return rest.InClusterConfig()
+}
+
+// ListTargets returns running pods labeled app=<app> in the configured
+// namespace, further filtered by selector. A target's Region is the name of
+// the node its pod runs on, so selector.Regions is matched against node
+// names on this backend.
+func (k *KubernetesSaboteur) ListTargets(ctx context.Context, app string, selector TargetSelector) ([]Target, error) {
+	pods, err := k.clientset.CoreV1().Pods(k.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", app),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+
+	var targets []Target
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		if !selector.matches(pod.Labels, pod.Spec.NodeName) {
+			continue
+		}
+
+		targets = append(targets, Target{
+			ID:       pod.Name,
+			Name:     pod.Name,
+			State:    string(pod.Status.Phase),
+			Region:   pod.Spec.NodeName,
+			Metadata: pod.Labels,
+		})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no eligible targets found for app %s in namespace %s", app, k.namespace)
+	}
+
+	return targets, nil
+}
+
+// RestartMachine deletes the named pod.
+func (k *KubernetesSaboteur) RestartMachine(ctx context.Context, app string, machineID string) error {
+	return k.StopMachine(ctx, app, machineID)
+}
+
+// StopMachine deletes the named pod, relying on its controller (Deployment,
+// StatefulSet, ...) to reschedule a replacement.
+func (k *KubernetesSaboteur) StopMachine(ctx context.Context, app string, machineID string) error {
+	if err := k.clientset.CoreV1().Pods(k.namespace).Delete(ctx, machineID, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("deleting pod %s: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// InjectLatency, StressCPU, and StressMemory run tc/stress-ng inside an
+// ephemeral debug container attached to the target pod, so the chaos
+// applies to the pod's network namespace without modifying its spec.
+func (k *KubernetesSaboteur) InjectLatency(ctx context.Context, app string, machineID string, latency domain.LatencyAction) error {
+	cmd := []string{"tc", "qdisc", "add", "dev", "eth0", "root", "netem",
+		"delay", fmt.Sprintf("%dms", latency.DurationMS), fmt.Sprintf("%dms", latency.JitterMS)}
+
+	return k.runDebugContainer(ctx, machineID, "inject-latency", cmd)
+}
+
+func (k *KubernetesSaboteur) StressCPU(ctx context.Context, app string, machineID string, stress domain.CPUStressAction) error {
+	cmd := []string{"stress-ng", "--cpu", fmt.Sprintf("%d", stress.Cores), "--timeout", stress.Duration.String()}
+
+	return k.runDebugContainer(ctx, machineID, "cpu-stress", cmd)
+}
+
+func (k *KubernetesSaboteur) StressMemory(ctx context.Context, app string, machineID string, stress domain.MemoryStressAction) error {
+	cmd := []string{"stress-ng", "--vm", "1", "--vm-bytes", fmt.Sprintf("%dM", stress.MB), "--timeout", stress.Duration.String()}
+
+	return k.runDebugContainer(ctx, machineID, "memory-stress", cmd)
+}
+
+// Partition drops all traffic on the target pod's primary interface via an
+// ephemeral debug container.
+func (k *KubernetesSaboteur) Partition(ctx context.Context, app string, machineID string) error {
+	return k.runDebugContainer(ctx, machineID, "network-partition",
+		[]string{"tc", "qdisc", "add", "dev", "eth0", "root", "netem", "loss", "100%"})
+}
+
+// Rollback is a no-op on Kubernetes: deleted pods are already replaced by
+// their controller, and stress-ng debug containers exit once their timeout
+// elapses. Rollback does not remove the tc rules that inject_latency and
+// network_partition add; they stay in the pod's network namespace after the
+// debug container exits.
+func (k *KubernetesSaboteur) Rollback(ctx context.Context, app string, history []AttackRecord) error {
+	return nil
+}
+
+// runDebugContainer attaches an ephemeral debug container to the named
+// pod and runs cmd inside it, then waits for it to terminate and reports
+// an error if it exited non-zero. The debug container shares the pod's
+// network namespace, so tc/stress-ng changes apply to the workload
+// container without requiring it to carry those binaries itself.
+func (k *KubernetesSaboteur) runDebugContainer(ctx context.Context, podName, namePrefix string, cmd []string) error {
+	containerName := fmt.Sprintf("gimli-%s", namePrefix)
+	debugContainer := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:    containerName,
+			Image:   k.debugImage,
+			Command: cmd,
+		},
+		TargetContainerName: "",
+	}
+
+	pod, err := k.clientset.CoreV1().Pods(k.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("getting pod %s: %w", podName, err)
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, debugContainer)
+
+	if _, err := k.clientset.CoreV1().Pods(k.namespace).UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("attaching debug container to pod %s: %w", podName, err)
+	}
+
+	return k.waitForDebugContainer(ctx, podName, containerName)
+}
+
+// waitForDebugContainer polls podName until its containerName ephemeral
+// container has terminated, returning an error if it exited non-zero or
+// ctx is done first. UpdateEphemeralContainers only patches the pod spec,
+// so this is the only way to know whether the command inside actually ran.
+func (k *KubernetesSaboteur) waitForDebugContainer(ctx context.Context, podName, containerName string) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for debug container %s: %w", containerName, ctx.Err())
+		case <-ticker.C:
+			pod, err := k.clientset.CoreV1().Pods(k.namespace).Get(ctx, podName, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("getting pod %s: %w", podName, err)
+			}
+
+			for _, status := range pod.Status.EphemeralContainerStatuses {
+				if status.Name != containerName || status.State.Terminated == nil {
+					continue
+				}
+
+				if status.State.Terminated.ExitCode != 0 {
+					return fmt.Errorf("debug container %s exited %d: %s", containerName,
+						status.State.Terminated.ExitCode, status.State.Terminated.Message)
+				}
+
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package saboteur
+
+import "fmt"
+
+// Factory constructs a Saboteur backend from its configuration block.
+// Backend implementations register a Factory under a unique name, usually
+// from an init() function, so that importing the backend package is enough
+// to make it available via New.
+type Factory func(cfg map[string]any) (Saboteur, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a saboteur backend factory under the given name. It panics
+// if a factory is already registered under that name, since that indicates
+// a programming error rather than a runtime condition.
+func Register(name string, factory Factory) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("saboteur: backend %q already registered", name))
+	}
+	registry[name] = factory
+}
+
+// New looks up the backend registered under name and constructs a Saboteur
+// from the given configuration.
+func New(name string, cfg map[string]any) (Saboteur, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("saboteur: unknown backend %q", name)
+	}
+
+	return factory(cfg)
+}
+
+// TargetSelector narrows ListTargets to a subset of a backend's resources.
+// A resource matches only if it carries every Labels key/value pair and,
+// when Regions is non-empty, its region is in that allow-list.
+type TargetSelector struct {
+	Labels  map[string]string
+	Regions []string
+}
+
+// matches reports whether labels and region satisfy s. A zero-value
+// TargetSelector matches everything.
+func (s TargetSelector) matches(labels map[string]string, region string) bool {
+	for key, value := range s.Labels {
+		if labels[key] != value {
+			return false
+		}
+	}
+
+	if len(s.Regions) == 0 {
+		return true
+	}
+
+	for _, allowed := range s.Regions {
+		if allowed == region {
+			return true
+		}
+	}
+
+	return false
+}
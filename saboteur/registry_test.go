@@ -0,0 +1,67 @@
+package saboteur
+
+import "testing"
+
+func TestTargetSelectorMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector TargetSelector
+		labels   map[string]string
+		region   string
+		want     bool
+	}{
+		{
+			name:     "zero value matches everything",
+			selector: TargetSelector{},
+			labels:   map[string]string{"env": "prod"},
+			region:   "us-east-1",
+			want:     true,
+		},
+		{
+			name:     "label match with no region filter",
+			selector: TargetSelector{Labels: map[string]string{"env": "prod"}},
+			labels:   map[string]string{"env": "prod", "team": "core"},
+			region:   "us-east-1",
+			want:     true,
+		},
+		{
+			name:     "mismatched label value fails",
+			selector: TargetSelector{Labels: map[string]string{"env": "prod"}},
+			labels:   map[string]string{"env": "staging"},
+			region:   "us-east-1",
+			want:     false,
+		},
+		{
+			name:     "region in allow-list",
+			selector: TargetSelector{Regions: []string{"us-east-1", "us-west-2"}},
+			labels:   map[string]string{},
+			region:   "us-west-2",
+			want:     true,
+		},
+		{
+			name:     "region not in allow-list",
+			selector: TargetSelector{Regions: []string{"us-east-1"}},
+			labels:   map[string]string{},
+			region:   "us-west-2",
+			want:     false,
+		},
+		{
+			name: "labels and regions must both match",
+			selector: TargetSelector{
+				Labels:  map[string]string{"env": "prod"},
+				Regions: []string{"us-east-1"},
+			},
+			labels: map[string]string{"env": "prod"},
+			region: "us-west-2",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.matches(tt.labels, tt.region); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}